@@ -16,12 +16,64 @@ import (
 	"gomatcha.io/matcha/view"
 )
 
+// StackAnimation enumerates the transition animations available to Stack.PushWithAnimation and
+// Stack.PopWithAnimation.
+type StackAnimation int
+
+const (
+	DefaultAnimation StackAnimation = iota
+	StartToEndAnimation
+	EndToStartAnimation
+	TopDownAnimation
+	BottomUpAnimation
+)
+
+// popTransaction tracks an in-flight PopWithAnimation call so its completion callback can be
+// matched up with the view it removed once the native side reports the transition finished.
+type popTransaction struct {
+	poppedView view.View
+	onFinished func(view.View)
+}
+
+// pendingTransition records the animation a child should transition in with on the next Build,
+// keyed by that child's id so that several pushes/pops queued before a Build don't clobber one
+// another's animation and transaction id.
+type pendingTransition struct {
+	anim StackAnimation
+	txId int64
+}
+
+// discardPendingTransition drops any transition queued for id before it ever reached a Build
+// call, along with the onFinished callback registered for its transaction id. Without this, a
+// transaction id that's removed (or overwritten) before Build can embed it into childrenPb is
+// never reported back via "OnTransitionFinished", so its callback would otherwise leak forever.
+func (s *Stack) discardPendingTransition(id int64) {
+	pt, ok := s.pendingTransitions[id]
+	if !ok {
+		return
+	}
+	delete(s.pendingTransitions, id)
+	delete(s.pushCallbacks, pt.txId)
+	delete(s.popCallbacks, pt.txId)
+}
+
 // Stack represents a list of views to be shown in the StackView. It can be manipulated outside of a Build() call.
 type Stack struct {
 	relay       comm.Relay
 	childIds    []int64
 	childrenMap map[int64]view.View
 	maxId       int64
+
+	maxTransactionId   int64
+	pendingTransitions map[int64]*pendingTransition // keyed by screen id
+
+	pushCallbacks map[int64]func()
+	popCallbacks  map[int64]*popTransaction
+
+	currentRelay  comm.Relay
+	currentId     int64 // id of the child pinned via SetCurrent; only meaningful if currentSet
+	currentSet    bool  // whether SetCurrent has pinned currentId
+	lastCurrentId int64
 }
 
 func (s *Stack) SetViews(vs ...view.View) {
@@ -35,11 +87,75 @@ func (s *Stack) SetViews(vs ...view.View) {
 		s.childrenMap[s.maxId] = i
 	}
 	s.relay.Signal()
+	s.signalCurrentChanged()
 }
 
 func (s *Stack) setChildIds(ids []int64) {
 	s.childIds = ids
+	s.currentSet = false // a native-driven change always resolves Current back to the new top
+	s.relay.Signal()
+	s.signalCurrentChanged()
+}
+
+// Current returns the index of the view that should be shown as the top of the stack. It is the
+// index of the view last pinned via SetCurrent, as long as that view is still in the stack, or
+// the index of the last view in the stack otherwise. Tracking the pinned view by identity, rather
+// than by its index at the time of the SetCurrent call, means Current keeps following that view
+// across Insert/Remove/Replace calls that shift other views around it.
+func (s *Stack) Current() int {
+	if s.currentSet {
+		for i, id := range s.childIds {
+			if id == s.currentId {
+				return i
+			}
+		}
+	}
+	return len(s.childIds) - 1
+}
+
+// SetCurrent designates the view at index i as the one to show, even if the user hasn't pushed
+// or popped to reach it.
+func (s *Stack) SetCurrent(i int) {
+	if i < 0 || i >= len(s.childIds) {
+		return
+	}
+	s.currentId = s.childIds[i]
+	s.currentSet = true
 	s.relay.Signal()
+	s.signalCurrentChanged()
+}
+
+// Peek returns the view at Current(), or nil if the stack is empty.
+func (s *Stack) Peek() view.View {
+	idx := s.Current()
+	if idx < 0 || idx >= len(s.childIds) {
+		return nil
+	}
+	return s.childrenMap[s.childIds[idx]]
+}
+
+// signalCurrentChanged fires currentRelay only when the identity of the view at Current() has
+// changed, as opposed to relay, which fires on any mutation of the stack.
+func (s *Stack) signalCurrentChanged() {
+	var id int64
+	idx := s.Current()
+	if idx >= 0 && idx < len(s.childIds) {
+		id = s.childIds[idx]
+	}
+	if id != s.lastCurrentId {
+		s.lastCurrentId = id
+		s.currentRelay.Signal()
+	}
+}
+
+// NotifyCurrentChanged registers f to be called whenever the view at Current() changes identity.
+func (s *Stack) NotifyCurrentChanged(f func()) comm.Id {
+	return s.currentRelay.Notify(f)
+}
+
+// UnnotifyCurrentChanged unregisters a function previously registered with NotifyCurrentChanged.
+func (s *Stack) UnnotifyCurrentChanged(id comm.Id) {
+	s.currentRelay.Unnotify(id)
 }
 
 func (s *Stack) Views() []view.View {
@@ -51,20 +167,183 @@ func (s *Stack) Views() []view.View {
 }
 
 func (s *Stack) Push(vs view.View) {
-	s.maxId += 1
+	s.PushWithAnimation(vs, DefaultAnimation, nil)
+}
 
-	s.childIds = append(s.childIds, s.maxId)
-	s.childrenMap[s.maxId] = vs
+// PushWithAnimation pushes v onto the top of the stack using anim. If onFinished is non-nil, it
+// is called once the native side reports that the transition has completed.
+func (s *Stack) PushWithAnimation(v view.View, anim StackAnimation, onFinished func()) {
+	if s.childrenMap == nil {
+		s.childrenMap = map[int64]view.View{}
+	}
+
+	s.maxId += 1
+	id := s.maxId
+	s.childIds = append(s.childIds, id)
+	s.childrenMap[id] = v
+
+	s.maxTransactionId += 1
+	txId := s.maxTransactionId
+	if s.pendingTransitions == nil {
+		s.pendingTransitions = map[int64]*pendingTransition{}
+	}
+	s.pendingTransitions[id] = &pendingTransition{anim: anim, txId: txId}
+	if onFinished != nil {
+		if s.pushCallbacks == nil {
+			s.pushCallbacks = map[int64]func(){}
+		}
+		s.pushCallbacks[txId] = onFinished
+	}
 	s.relay.Signal()
+	s.signalCurrentChanged()
 }
 
 func (s *Stack) Pop() {
+	s.PopWithAnimation(DefaultAnimation, nil)
+}
+
+// PopWithAnimation removes the top view of the stack using anim, returning false if the stack
+// has one or fewer views. If onFinished is non-nil, it is called with the popped view once the
+// native side reports that the transition has completed.
+func (s *Stack) PopWithAnimation(anim StackAnimation, onFinished func(view.View)) bool {
 	if len(s.childIds) <= 1 {
-		return
+		return false
 	}
-	delete(s.childrenMap, s.childIds[len(s.childIds)-1])
+
+	id := s.childIds[len(s.childIds)-1]
+	popped := s.childrenMap[id]
+	delete(s.childrenMap, id)
+	s.discardPendingTransition(id) // the popped screen can no longer transition in
 	s.childIds = s.childIds[:len(s.childIds)-1]
+
+	newTopId := s.childIds[len(s.childIds)-1]
+	s.discardPendingTransition(newTopId) // don't orphan a transition newTopId was already queued for
+	s.maxTransactionId += 1
+	txId := s.maxTransactionId
+	if s.pendingTransitions == nil {
+		s.pendingTransitions = map[int64]*pendingTransition{}
+	}
+	s.pendingTransitions[newTopId] = &pendingTransition{anim: anim, txId: txId}
+	if onFinished != nil {
+		if s.popCallbacks == nil {
+			s.popCallbacks = map[int64]*popTransaction{}
+		}
+		s.popCallbacks[txId] = &popTransaction{poppedView: popped, onFinished: onFinished}
+	}
 	s.relay.Signal()
+	s.signalCurrentChanged()
+	return true
+}
+
+// MoveToFront moves v to the top of the stack without reinstantiating it, returning false if v
+// is not currently in the stack.
+func (s *Stack) MoveToFront(v view.View) bool {
+	for id, child := range s.childrenMap {
+		if child == v {
+			return s.MoveToFrontByID(id)
+		}
+	}
+	return false
+}
+
+// MoveToFrontByID moves the child with the given id to the top of the stack without
+// reinstantiating it, returning false if no child with that id exists.
+func (s *Stack) MoveToFrontByID(id int64) bool {
+	idx := -1
+	for i, cid := range s.childIds {
+		if cid == id {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return false
+	}
+
+	s.childIds = append(s.childIds[:idx], s.childIds[idx+1:]...)
+	s.childIds = append(s.childIds, id)
+	s.relay.Signal()
+	s.signalCurrentChanged()
+	return true
+}
+
+// Insert adds v at index, shifting later views back one position. Because it only splices
+// childIds, every other view keeps the id it already had, so the native side sees a minimal
+// insert rather than a full stack rebuild.
+func (s *Stack) Insert(index int, v view.View) {
+	if index < 0 || index > len(s.childIds) {
+		return
+	}
+	if s.childrenMap == nil {
+		s.childrenMap = map[int64]view.View{}
+	}
+
+	s.maxId += 1
+	id := s.maxId
+	s.childrenMap[id] = v
+
+	s.childIds = append(s.childIds, 0)
+	copy(s.childIds[index+1:], s.childIds[index:])
+	s.childIds[index] = id
+
+	s.relay.Signal()
+	s.signalCurrentChanged()
+}
+
+// Remove removes and returns the view at index, or nil if index is out of range. Like Pop, it
+// refuses to remove the last remaining view, since a Stack always shows a root. The ids of the
+// remaining views are untouched.
+func (s *Stack) Remove(index int) view.View {
+	if index < 0 || index >= len(s.childIds) || len(s.childIds) <= 1 {
+		return nil
+	}
+
+	id := s.childIds[index]
+	v := s.childrenMap[id]
+	delete(s.childrenMap, id)
+	s.discardPendingTransition(id)
+	s.childIds = append(s.childIds[:index], s.childIds[index+1:]...)
+
+	s.relay.Signal()
+	s.signalCurrentChanged()
+	return v
+}
+
+// RemoveByID removes and returns the view with the given id, or nil if no such view exists. Like
+// Remove, it refuses to remove the last remaining view.
+func (s *Stack) RemoveByID(id int64) view.View {
+	for i, cid := range s.childIds {
+		if cid == id {
+			return s.Remove(i)
+		}
+	}
+	return nil
+}
+
+// Replace swaps the view at index for v, returning the view that was there, or nil if index is
+// out of range. The id at index is reused, so the native side treats this as an in-place update
+// rather than a pop/push of the surrounding views.
+func (s *Stack) Replace(index int, v view.View) view.View {
+	if index < 0 || index >= len(s.childIds) {
+		return nil
+	}
+
+	id := s.childIds[index]
+	old := s.childrenMap[id]
+	s.childrenMap[id] = v
+
+	s.relay.Signal()
+	s.signalCurrentChanged()
+	return old
+}
+
+// SetRoot replaces the bottom of the stack with v, leaving every other view untouched.
+func (s *Stack) SetRoot(v view.View) {
+	if len(s.childIds) == 0 {
+		s.SetViews(v)
+		return
+	}
+	s.Replace(0, v)
 }
 
 func (s *Stack) Notify(f func()) comm.Id {
@@ -104,7 +383,6 @@ Modifying the stack:
 	child := view.NewBasicView()
 	child.Painter = &paint.Style{BackgroundColor: colornames.Green}
 	v.Stack.Push(child)
-
 */
 type StackView struct {
 	view.Embed
@@ -113,6 +391,16 @@ type StackView struct {
 	TitleStyle *text.Style
 	BackStyle  *text.Style
 	BarColor   color.Color
+
+	// InteractivePopGestureDisabled turns off UINavigationController's interactivePopGestureRecognizer,
+	// which otherwise lets the user swipe from the left edge to pop the top view. It defaults to
+	// enabled (the zero value), matching UINavigationController's own default, so existing
+	// StackViews don't lose swipe-back just by picking up this field.
+	InteractivePopGestureDisabled bool
+	// OnInteractivePopProgress, if non-nil, is called repeatedly while the user drags the swipe-back
+	// gesture, with fraction reporting how far the gesture has progressed and cancelled reporting
+	// whether the gesture ended by completing the pop (false) or returning to the top view (true).
+	OnInteractivePopProgress func(fraction float64, cancelled bool)
 }
 
 // NewStackView returns a new view.
@@ -187,9 +475,21 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 			s.HeightEqual(l.MaxGuide().Height().Add(-64)) // TODO(KD): Respect bar actual height, shorter when rotated, etc...
 		})
 
-		// Add ids to protobuf.
+		// Add ids to protobuf, tagging any child with a queued transition with the animation and
+		// transaction id the native side needs to drive and report it. Consuming (deleting) the
+		// entry here, rather than clearing a single shared slot, means several pushes/pops queued
+		// before this Build each keep their own animation instead of clobbering one another.
+		var anim StackAnimation
+		var txId int64
+		if pt, ok := v.Stack.pendingTransitions[id]; ok {
+			anim = pt.anim
+			txId = pt.txId
+			delete(v.Stack.pendingTransitions, id)
+		}
 		childrenPb = append(childrenPb, &pbios.StackChildView{
-			ScreenId: int64(id),
+			ScreenId:      int64(id),
+			Animation:     pbios.StackAnimation(anim),
+			TransactionId: txId,
 		})
 	}
 
@@ -203,15 +503,24 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 		backTextStyle = v.BackStyle.MarshalProtobuf()
 	}
 
+	// Current() returns -1 for an empty stack; clamp to 0 so the native side, which expects
+	// Current to index a real child, never sees a negative index.
+	current := v.Stack.Current()
+	if current < 0 {
+		current = 0
+	}
+
 	return view.Model{
 		Children:       l.Views(),
 		Layouter:       l,
 		NativeViewName: "gomatcha.io/matcha/view/stacknav",
 		NativeViewState: internal.MarshalProtobuf(&pbios.StackView{
-			Children:       childrenPb,
-			TitleTextStyle: titleTextStyle,
-			BackTextStyle:  backTextStyle,
-			BarColor:       pb.ColorEncode(v.BarColor),
+			Children:                     childrenPb,
+			Current:                      int64(current),
+			TitleTextStyle:               titleTextStyle,
+			BackTextStyle:                backTextStyle,
+			BarColor:                     pb.ColorEncode(v.BarColor),
+			InteractivePopGestureEnabled: !v.InteractivePopGestureDisabled,
 		}),
 		NativeFuncs: map[string]interface{}{
 			"OnChange": func(data []byte) {
@@ -224,6 +533,36 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 
 				v.Stack.setChildIds(pbevent.Id)
 			},
+			"OnTransitionFinished": func(data []byte) {
+				pbevent := &pbios.StackTransitionEvent{}
+				err := proto.Unmarshal(data, pbevent)
+				if err != nil {
+					fmt.Println("error", err)
+					return
+				}
+
+				txId := pbevent.TransactionId
+				if cb, ok := v.Stack.pushCallbacks[txId]; ok {
+					delete(v.Stack.pushCallbacks, txId)
+					cb()
+				}
+				if pt, ok := v.Stack.popCallbacks[txId]; ok {
+					delete(v.Stack.popCallbacks, txId)
+					pt.onFinished(pt.poppedView)
+				}
+			},
+			"OnPopGesture": func(data []byte) {
+				pbevent := &pbios.StackGestureEvent{}
+				err := proto.Unmarshal(data, pbevent)
+				if err != nil {
+					fmt.Println("error", err)
+					return
+				}
+
+				if v.OnInteractivePopProgress != nil {
+					v.OnInteractivePopProgress(pbevent.Fraction, pbevent.Cancelled)
+				}
+			},
 		},
 	}
 }
@@ -269,22 +608,67 @@ func (v *stackBarView) Build(ctx view.Context) view.Model {
 		})
 	}
 
+	hasSearchBar := false
+	var searchPlaceholder string
+	if v.Bar.SearchBar != nil {
+		hasSearchBar = true
+		searchPlaceholder = v.Bar.SearchBar.Placeholder
+	}
+
 	return view.Model{
 		Layouter:       l,
 		Children:       l.Views(),
 		NativeViewName: "gomatcha.io/matcha/view/stacknav Bar",
 		NativeViewState: internal.MarshalProtobuf(&pbios.StackBar{
-			Title: v.Bar.Title,
+			Title:                 v.Bar.Title,
 			CustomBackButtonTitle: len(v.Bar.BackButtonTitle) > 0,
 			BackButtonTitle:       v.Bar.BackButtonTitle,
 			BackButtonHidden:      v.Bar.BackButtonHidden,
 			HasTitleView:          hasTitleView,
 			RightViewCount:        rightViewCount,
 			LeftViewCount:         leftViewCount,
+			LargeTitle:            v.Bar.LargeTitle,
+			LargeTitleDisplayMode: pbios.LargeTitleDisplayMode(v.Bar.LargeTitleDisplayMode),
+			Translucent:           v.Bar.Translucent,
+			PrefersHidden:         v.Bar.PrefersHidden,
+			ScrollEdgeBarColor:    pb.ColorEncode(v.Bar.ScrollEdgeBarColor),
+			ShadowColor:           pb.ColorEncode(v.Bar.ShadowColor),
+			HasSearchBar:          hasSearchBar,
+			SearchPlaceholder:     searchPlaceholder,
 		}),
+		NativeFuncs: map[string]interface{}{
+			"OnSearchTextChanged": func(data []byte) {
+				pbevent := &pbios.SearchBarEvent{}
+				err := proto.Unmarshal(data, pbevent)
+				if err != nil {
+					fmt.Println("error", err)
+					return
+				}
+
+				if v.Bar.SearchBar != nil && v.Bar.SearchBar.OnTextChanged != nil {
+					v.Bar.SearchBar.OnTextChanged(pbevent.Text)
+				}
+			},
+		},
 	}
 }
 
+// LargeTitleDisplayMode controls whether a StackBar's title renders large, mirroring
+// UINavigationItem.LargeTitleDisplayMode.
+type LargeTitleDisplayMode int
+
+const (
+	LargeTitleDisplayModeAutomatic LargeTitleDisplayMode = iota
+	LargeTitleDisplayModeAlways
+	LargeTitleDisplayModeNever
+)
+
+// SearchBar configures the search field embedded in a StackBar.
+type SearchBar struct {
+	Placeholder   string
+	OnTextChanged func(string)
+}
+
 type StackBar struct {
 	Title            string
 	BackButtonTitle  string
@@ -293,6 +677,14 @@ type StackBar struct {
 	TitleView  view.View
 	RightViews []view.View
 	LeftViews  []view.View
+
+	LargeTitle            bool
+	LargeTitleDisplayMode LargeTitleDisplayMode
+	Translucent           bool
+	PrefersHidden         bool
+	ScrollEdgeBarColor    color.Color
+	ShadowColor           color.Color
+	SearchBar             *SearchBar
 }
 
 func (t *StackBar) OptionKey() string {
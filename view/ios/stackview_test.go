@@ -0,0 +1,191 @@
+package ios
+
+import (
+	"testing"
+
+	"gomatcha.io/matcha/view"
+)
+
+// testView is a minimal view.View used to exercise Stack without depending on any particular
+// leaf view implementation.
+type testView struct {
+	view.Embed
+}
+
+func (t *testView) Build(ctx view.Context) view.Model {
+	return view.Model{}
+}
+
+func TestStackSetCurrent_TracksIdentityThroughInsert(t *testing.T) {
+	s := &Stack{}
+	a, b, c := &testView{}, &testView{}, &testView{}
+	s.SetViews(a, b, c)
+
+	s.SetCurrent(1) // pin b
+	if s.Peek() != b {
+		t.Fatalf("Peek() = %v, want b", s.Peek())
+	}
+
+	s.Insert(0, &testView{}) // shifts a, b, c right by one
+	if got := s.Peek(); got != b {
+		t.Fatalf("after Insert, Peek() = %v, want b (pinned view should follow the shift)", got)
+	}
+	if got := s.Current(); got != 2 {
+		t.Fatalf("after Insert, Current() = %d, want 2", got)
+	}
+}
+
+func TestStackSetCurrent_FallsBackToTopOnceRemoved(t *testing.T) {
+	s := &Stack{}
+	a, b := &testView{}, &testView{}
+	s.SetViews(a, b)
+
+	s.SetCurrent(0) // pin a
+	s.RemoveByID(s.childIds[0])
+
+	if got := s.Peek(); got != b {
+		t.Fatalf("Peek() = %v, want b (fallback to top once the pinned view is gone)", got)
+	}
+}
+
+func TestStackPop_RefusesLastView(t *testing.T) {
+	s := &Stack{}
+	a := &testView{}
+	s.SetViews(a)
+
+	if ok := s.PopWithAnimation(DefaultAnimation, nil); ok {
+		t.Fatal("PopWithAnimation on a single-view stack returned true, want false")
+	}
+	if len(s.childIds) != 1 {
+		t.Fatalf("len(childIds) = %d, want 1", len(s.childIds))
+	}
+}
+
+func TestStackRemove_RefusesLastView(t *testing.T) {
+	s := &Stack{}
+	a := &testView{}
+	s.SetViews(a)
+
+	if got := s.Remove(0); got != nil {
+		t.Fatalf("Remove on a single-view stack = %v, want nil", got)
+	}
+	if len(s.childIds) != 1 {
+		t.Fatalf("len(childIds) = %d, want 1", len(s.childIds))
+	}
+}
+
+func TestStackPushWithAnimation_QueuesIndependentTransactions(t *testing.T) {
+	s := &Stack{}
+	s.SetViews(&testView{})
+
+	var firstFinished, secondFinished bool
+	s.PushWithAnimation(&testView{}, StartToEndAnimation, func() { firstFinished = true })
+	firstId := s.childIds[len(s.childIds)-1]
+	s.PushWithAnimation(&testView{}, EndToStartAnimation, func() { secondFinished = true })
+	secondId := s.childIds[len(s.childIds)-1]
+
+	first, ok := s.pendingTransitions[firstId]
+	if !ok {
+		t.Fatalf("pendingTransitions missing entry for the first push; it was clobbered by the second")
+	}
+	second, ok := s.pendingTransitions[secondId]
+	if !ok {
+		t.Fatalf("pendingTransitions missing entry for the second push")
+	}
+	if first.anim != StartToEndAnimation || second.anim != EndToStartAnimation {
+		t.Fatalf("got anims %v, %v; want %v, %v", first.anim, second.anim, StartToEndAnimation, EndToStartAnimation)
+	}
+	if first.txId == second.txId {
+		t.Fatalf("both pushes were assigned the same transaction id %d", first.txId)
+	}
+
+	if cb, ok := s.pushCallbacks[first.txId]; !ok {
+		t.Fatal("missing push callback for the first transaction")
+	} else {
+		cb()
+	}
+	if cb, ok := s.pushCallbacks[second.txId]; !ok {
+		t.Fatal("missing push callback for the second transaction")
+	} else {
+		cb()
+	}
+	if !firstFinished || !secondFinished {
+		t.Fatalf("firstFinished=%v secondFinished=%v, want both true", firstFinished, secondFinished)
+	}
+}
+
+func TestStackMoveToFront(t *testing.T) {
+	s := &Stack{}
+	a, b, c := &testView{}, &testView{}, &testView{}
+	s.SetViews(a, b, c)
+
+	if ok := s.MoveToFront(a); !ok {
+		t.Fatal("MoveToFront(a) = false, want true")
+	}
+	if got := s.Views(); got[0] != b || got[1] != c || got[2] != a {
+		t.Fatalf("Views() = %v, want [b, c, a]", got)
+	}
+
+	bId := s.childIds[0]
+	if ok := s.MoveToFrontByID(bId); !ok {
+		t.Fatal("MoveToFrontByID(bId) = false, want true")
+	}
+	if got := s.Views(); got[0] != c || got[1] != a || got[2] != b {
+		t.Fatalf("Views() = %v, want [c, a, b]", got)
+	}
+
+	if ok := s.MoveToFront(&testView{}); ok {
+		t.Fatal("MoveToFront on a view not in the stack = true, want false")
+	}
+	if ok := s.MoveToFrontByID(-1); ok {
+		t.Fatal("MoveToFrontByID with an unknown id = true, want false")
+	}
+}
+
+func TestStackPushThenPop_DiscardsOrphanedCallback(t *testing.T) {
+	s := &Stack{}
+	s.SetViews(&testView{})
+
+	var finished bool
+	s.PushWithAnimation(&testView{}, DefaultAnimation, func() { finished = true })
+	// Popping the just-pushed screen before a Build ever consumes its transaction means the
+	// native side will never see that transaction id, so its callback must not linger either.
+	s.PopWithAnimation(DefaultAnimation, nil)
+
+	if len(s.pushCallbacks) != 0 {
+		t.Fatalf("pushCallbacks = %v, want empty (orphaned callback was not discarded)", s.pushCallbacks)
+	}
+}
+
+func TestStackInsertRemoveReplace_PreservesIds(t *testing.T) {
+	s := &Stack{}
+	a, b, c := &testView{}, &testView{}, &testView{}
+	s.SetViews(a, b, c)
+	bId := s.childIds[1]
+	cId := s.childIds[2]
+
+	s.Insert(0, &testView{})
+	if s.childrenMap[bId] != b || s.childrenMap[cId] != c {
+		t.Fatal("Insert changed the id of an untouched view")
+	}
+
+	removed := s.Remove(0) // removes the view just inserted
+	if removed == nil {
+		t.Fatal("Remove returned nil for a valid index")
+	}
+	if s.childrenMap[bId] != b || s.childrenMap[cId] != c {
+		t.Fatal("Remove changed the id of an untouched view")
+	}
+
+	d := &testView{}
+	old := s.Replace(0, d)
+	if old != a {
+		t.Fatalf("Replace returned %v, want a", old)
+	}
+	if s.childrenMap[s.childIds[0]] != d {
+		t.Fatal("Replace did not install the new view at index 0")
+	}
+	if s.childrenMap[bId] != b || s.childrenMap[cId] != c {
+		t.Fatal("Replace changed the id of an untouched view")
+	}
+}